@@ -15,11 +15,13 @@
 package convert
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl/hcl/token"
@@ -39,9 +41,16 @@ import (
 const (
 	LanguageTypescript string = "typescript"
 	LanguagePython     string = "python"
+	LanguageGo         string = "go"
+	LanguageCSharp     string = "csharp"
 )
 
 var (
+	// ValidLanguages lists the languages newGenerator can actually produce code for today. LanguageGo and
+	// LanguageCSharp are reserved identifiers for forthcoming gen/gopulumi and gen/dotnet generators; they are
+	// deliberately left out of this list until those generators exist, so that callers validating a --language
+	// flag against ValidLanguages reject "go"/"csharp" up front with a clear error instead of passing validation
+	// and failing deep inside codegen.
 	ValidLanguages = [...]string{LanguageTypescript, LanguagePython}
 )
 
@@ -84,31 +93,80 @@ func addLocationAnnotations(m *il.Graph) {
 	}
 }
 
-// Convert converts a Terraform module at the provided location into a Pulumi module, written to stdout.
+// Convert converts a Terraform module at the provided location into a Pulumi module, written to opts.Writer (stdout
+// by default). It is a thin wrapper around Eject that writes the resulting files--in sorted filename order, to keep
+// output deterministic--to a single stream; embedders that want the generated files as a map, e.g. to lay them out
+// as a project directory, should call Eject directly.
 func Convert(opts Options) error {
-	// Set default options where appropriate.
-	if opts.Path == "" {
-		opts.Path = "."
+	if opts.EmitProjectFiles {
+		return errors.New("EmitProjectFiles is not supported by Convert, which writes a single undifferentiated " +
+			"stream to opts.Writer; call Eject instead to get the generated project files back as a map")
 	}
 	if opts.Writer == nil {
 		opts.Writer = os.Stdout
 	}
 
+	files, diags, err := Eject(opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Logger != nil {
+		for _, d := range diags {
+			opts.Logger.Println(d.String())
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if _, err := opts.Writer.Write(files[name]); err != nil {
+			return errors.Wrapf(err, "writing %s", name)
+		}
+	}
+
+	return nil
+}
+
+// Eject converts a Terraform module at the provided location into a Pulumi module and returns the generated files
+// as a map from filename to contents, along with any non-fatal Diagnostics encountered along the way, instead of
+// writing the result to opts.Writer. This is the entry point for callers that embed tf2pulumi as a library, e.g. a
+// `pulumi convert`-style command that needs to place the output into a project layout (index.ts + package.json,
+// __main__.py + Pulumi.yaml, etc.) rather than a single stdout stream.
+func Eject(opts Options) (map[string][]byte, Diagnostics, error) {
+	// Set default options where appropriate.
+	if opts.Path == "" {
+		opts.Path = "."
+	}
+
 	services := disco.NewWithCredentialsSource(noCredentials{})
 	moduleStorage := module.NewStorage(filepath.Join(command.DefaultDataDir, "modules"), services)
 
 	mod, err := module.NewTreeModule("", opts.Path)
 	if err != nil {
-		return errors.Wrapf(err, "creating tree module")
+		return nil, nil, errors.Wrapf(err, "creating tree module")
 	}
 
 	if err = mod.Load(moduleStorage); err != nil {
-		return errors.Wrapf(err, "loading module")
+		return nil, nil, errors.Wrapf(err, "loading module")
 	}
 
-	gs, err := buildGraphs(mod, true, opts)
+	// il.BuildGraph reports missing providers, missing variables, and comment-extraction failures--tolerated
+	// because of the corresponding Allow* option--by logging them through BuildOptions.Logger rather than
+	// returning them as data. Redirect that logger to a collector for the duration of this call so those messages
+	// come back as Diagnostics; opts.Logger itself is left untouched here; it's up to the caller to do something
+	// with the returned Diagnostics (Convert, for instance, logs them to opts.Logger once it gets them back).
+	collector := &diagnosticCollector{}
+	graphOpts := opts
+	graphOpts.Logger = log.New(collector, "", 0)
+
+	gs, err := buildGraphs(mod, true, graphOpts)
 	if err != nil {
-		return errors.Wrapf(err, "importing Terraform project graphs")
+		return nil, nil, errors.Wrapf(err, "importing Terraform project graphs")
 	}
 
 	// Filter resource name properties if requested.
@@ -136,16 +194,66 @@ func Convert(opts Options) error {
 		}
 	}
 
-	generator, err := newGenerator("auto", opts)
+	var buf bytes.Buffer
+	genOpts := opts
+	genOpts.Writer = &buf
+
+	// The project name only matters to the generator when it has to agree with an emitted Pulumi.yaml/package
+	// manifest; callers that don't ask for EmitProjectFiles keep getting the pre-existing unconditional "auto"
+	// that Convert has always passed to the generator, so this change doesn't alter their generated output.
+	projectName := "auto"
+	if opts.EmitProjectFiles {
+		projectName = projectNameOrDefault(opts)
+	}
+	generator, err := newGenerator(projectName, genOpts)
 	if err != nil {
-		return errors.Wrapf(err, "creating generator")
+		return nil, nil, errors.Wrapf(err, "creating generator")
 	}
 
 	if err = gen.Generate(gs, generator); err != nil {
-		return errors.Wrapf(err, "generating code")
+		return nil, nil, errors.Wrapf(err, "generating code")
 	}
 
-	return nil
+	files := map[string][]byte{
+		entrypointFilename(opts.TargetLanguage): buf.Bytes(),
+	}
+
+	if opts.EmitProjectFiles {
+		projectFiles, err := projectScaffold(opts, gs, projectName)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "emitting project files")
+		}
+		for name, contents := range projectFiles {
+			files[name] = contents
+		}
+	}
+
+	return files, collector.diagnostics, nil
+}
+
+// projectNameOrDefault returns opts.ProjectName, falling back to a fixed default when unset. When
+// Options.EmitProjectFiles is set, Eject uses it as the single source of truth for the project name, so that the
+// generated program (via newGenerator) and the emitted Pulumi.yaml/package manifest agree on it; otherwise Eject
+// passes "auto" to newGenerator and does not call this function.
+func projectNameOrDefault(opts Options) string {
+	if opts.ProjectName != "" {
+		return opts.ProjectName
+	}
+	return "tf2pulumi"
+}
+
+// diagnosticCollector is an io.Writer that turns lines logged by il.BuildGraph (missing providers, missing
+// variables, comment-extraction failures--each tolerated only because of the corresponding Allow* option) into
+// Diagnostics, so that Eject callers can inspect them as data instead of scraping log output.
+type diagnosticCollector struct {
+	diagnostics Diagnostics
+}
+
+func (c *diagnosticCollector) Write(p []byte) (int, error) {
+	if msg := strings.TrimRight(string(p), "\n"); msg != "" {
+		c.diagnostics = c.diagnostics.append(msg)
+	}
+	return len(p), nil
 }
 
 type Options struct {
@@ -178,6 +286,19 @@ type Options struct {
 	// The target SDK version.
 	TargetSDKVersion string
 
+	// EmitProjectFiles, if true, causes Eject to include a full Pulumi project scaffold alongside the translated
+	// program: Pulumi.yaml plus the language-appropriate package manifest (package.json and tsconfig.json for
+	// TypeScript, requirements.txt for Python). The result is writable directly into an empty directory to yield a
+	// runnable `pulumi up` project. Convert rejects this option, since it only knows how to write a single
+	// undifferentiated stream to Writer; use Eject directly to get the scaffold files. Only TargetLanguage values
+	// that newGenerator can actually produce code for support this option today.
+	EmitProjectFiles bool
+	// ProjectName is the Pulumi project name to record in Pulumi.yaml and the language package manifest when
+	// EmitProjectFiles is set. Defaults to "tf2pulumi" if empty.
+	ProjectName string
+	// ProjectDescription is the Pulumi project description to record in Pulumi.yaml when EmitProjectFiles is set.
+	ProjectDescription string
+
 	// TargetOptions captures any target-specific options.
 	TargetOptions interface{}
 }
@@ -233,6 +354,12 @@ func newGenerator(projectName string, opts Options) (gen.Generator, error) {
 		return nodejs.New(projectName, opts.TargetSDKVersion, nodeOpts.UsePromptDataSources, opts.Writer)
 	case LanguagePython:
 		return python.New(projectName, opts.Writer), nil
+	case LanguageGo, LanguageCSharp:
+		// TODO: wire up gen/gopulumi and gen/dotnet generators, respectively, once those packages exist. These
+		// constants are reserved for that work but deliberately excluded from ValidLanguages until then, so this
+		// case should only be reachable when a caller sets opts.TargetLanguage directly rather than validating
+		// against ValidLanguages first.
+		return nil, errors.Errorf("code generation for language '%s' is not yet implemented", opts.TargetLanguage)
 	default:
 		return nil, errors.Errorf("invalid language '%s', expected one of %s",
 			opts.TargetLanguage, strings.Join(ValidLanguages[:], ", "))
@@ -0,0 +1,40 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+// Diagnostic describes a single non-fatal problem encountered while converting a Terraform module, such as a
+// missing provider, a reference to an undefined variable, or a failure to extract a comment from the source.
+//
+// Today these are derived from the plain-text lines il.BuildGraph logs when an Allow* option tolerates such a
+// problem, so a Diagnostic carries only a human-readable summary. Severity and source-position fields were tried
+// here and dropped: il.BuildGraph has no structured form of these problems to report, so those fields could never
+// hold anything but a zero value. Add them back once il.BuildGraph can report diagnostics as data rather than log
+// lines.
+type Diagnostic struct {
+	// Summary is a short, human-readable description of the diagnostic.
+	Summary string
+}
+
+func (d Diagnostic) String() string {
+	return d.Summary
+}
+
+// Diagnostics is an ordered collection of Diagnostic values accumulated over the course of a conversion.
+type Diagnostics []Diagnostic
+
+// append records a diagnostic with the given summary against the receiver, returning the updated slice.
+func (ds Diagnostics) append(summary string) Diagnostics {
+	return append(ds, Diagnostic{Summary: summary})
+}
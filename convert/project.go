@@ -0,0 +1,187 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/tf2pulumi/il"
+)
+
+// entrypointFilename returns the name of the file that holds the translated program for the given target
+// language, matching the layout `pulumi new`/`pulumi convert` expect for that language's project template.
+//
+// LanguageGo and LanguageCSharp have no case here: newGenerator errors out for both before Eject ever reaches this
+// function, since gen/gopulumi and gen/dotnet don't exist yet. Add cases for them once those generators land.
+func entrypointFilename(language string) string {
+	switch language {
+	case LanguageTypescript:
+		return "index.ts"
+	case LanguagePython:
+		return "__main__.py"
+	default:
+		return "index.ts"
+	}
+}
+
+// referencedProviders returns the sorted set of Terraform provider names referenced by resources in the given
+// graphs, e.g. "aws" for a graph containing an "aws_instance" resource. This drives which provider SDKs are pinned
+// into the emitted project scaffold.
+func referencedProviders(gs []*il.Graph) []string {
+	seen := map[string]bool{}
+	for _, g := range gs {
+		for _, r := range g.Resources {
+			if provider, _, ok := splitResourceType(r.Type); ok {
+				seen[provider] = true
+			}
+		}
+	}
+
+	providers := make([]string, 0, len(seen))
+	for p := range seen {
+		providers = append(providers, p)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// splitResourceType splits a Terraform resource type such as "aws_s3_bucket" into its provider name ("aws") and
+// the remainder ("s3_bucket").
+func splitResourceType(resourceType string) (provider, rest string, ok bool) {
+	idx := strings.IndexByte(resourceType, '_')
+	if idx <= 0 {
+		return "", "", false
+	}
+	return resourceType[:idx], resourceType[idx+1:], true
+}
+
+// projectScaffold returns the set of project files--Pulumi.yaml plus the language-specific package manifest and,
+// for TypeScript, a tsconfig.json--needed to turn the translated program returned by Eject into a project that
+// `pulumi up` can run directly. It is only invoked when Options.EmitProjectFiles is set. projectName must be the
+// same name used to construct the generator for this Eject call, so that the emitted manifest and the generated
+// program agree on the project's name.
+//
+// LanguageGo and LanguageCSharp have no case here for the same reason entrypointFilename doesn't: newGenerator
+// already rejects both before Eject calls this function.
+func projectScaffold(opts Options, gs []*il.Graph, projectName string) (map[string][]byte, error) {
+	runtime, ok := pulumiRuntime(opts.TargetLanguage)
+	if !ok {
+		return nil, fmt.Errorf("project file emission is not supported for language '%s'", opts.TargetLanguage)
+	}
+
+	files := map[string][]byte{
+		"Pulumi.yaml": []byte(pulumiYAML(projectName, opts.ProjectDescription, runtime)),
+	}
+
+	providers := referencedProviders(gs)
+
+	switch opts.TargetLanguage {
+	case LanguageTypescript:
+		files["package.json"] = []byte(nodePackageJSON(projectName, opts.TargetSDKVersion, providers))
+		files["tsconfig.json"] = []byte(nodeTSConfig())
+	case LanguagePython:
+		files["requirements.txt"] = []byte(pythonRequirements(opts.TargetSDKVersion, providers))
+	}
+
+	return files, nil
+}
+
+func pulumiRuntime(language string) (string, bool) {
+	switch language {
+	case LanguageTypescript:
+		return "nodejs", true
+	case LanguagePython:
+		return "python", true
+	default:
+		return "", false
+	}
+}
+
+func pulumiYAML(name, description, runtime string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "name: %s\n", name)
+	if description != "" {
+		fmt.Fprintf(&b, "description: %s\n", description)
+	}
+	fmt.Fprintf(&b, "runtime: %s\n", runtime)
+	return b.String()
+}
+
+func nodePackageJSON(name, sdkVersion string, providers []string) string {
+	var deps strings.Builder
+	fmt.Fprintf(&deps, "    \"@pulumi/pulumi\": %q", npmVersion(sdkVersion))
+	for _, p := range providers {
+		fmt.Fprintf(&deps, ",\n    \"@pulumi/%s\": %q", p, npmVersion(sdkVersion))
+	}
+	return fmt.Sprintf(`{
+    "name": %q,
+    "main": "index.ts",
+    "devDependencies": {
+        "@types/node": "^14.0.0",
+        "typescript": "^4.0.0"
+    },
+    "dependencies": {
+%s
+    }
+}
+`, name, deps.String())
+}
+
+func npmVersion(sdkVersion string) string {
+	if sdkVersion == "" {
+		return "latest"
+	}
+	return "^" + sdkVersion
+}
+
+func nodeTSConfig() string {
+	return `{
+    "compilerOptions": {
+        "strict": true,
+        "outDir": "bin",
+        "target": "es2016",
+        "module": "commonjs",
+        "moduleResolution": "node",
+        "sourceMap": true,
+        "experimentalDecorators": true,
+        "pretty": true,
+        "noFallthroughCasesInSwitch": true,
+        "noImplicitReturns": true,
+        "forceConsistentCasingInFileNames": true
+    },
+    "files": [
+        "index.ts"
+    ]
+}
+`
+}
+
+func pythonRequirements(sdkVersion string, providers []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "pulumi%s\n", pipVersion(sdkVersion))
+	for _, p := range providers {
+		fmt.Fprintf(&b, "pulumi-%s%s\n", p, pipVersion(sdkVersion))
+	}
+	return b.String()
+}
+
+func pipVersion(sdkVersion string) string {
+	if sdkVersion == "" {
+		return ""
+	}
+	return ">=" + sdkVersion
+}
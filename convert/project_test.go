@@ -0,0 +1,98 @@
+// Copyright 2016-2018, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package convert
+
+import "testing"
+
+func TestEntrypointFilename(t *testing.T) {
+	cases := []struct {
+		language string
+		want     string
+	}{
+		{LanguageTypescript, "index.ts"},
+		{LanguagePython, "__main__.py"},
+		{"unknown", "index.ts"},
+	}
+	for _, c := range cases {
+		if got := entrypointFilename(c.language); got != c.want {
+			t.Errorf("entrypointFilename(%q) = %q, want %q", c.language, got, c.want)
+		}
+	}
+}
+
+func TestSplitResourceType(t *testing.T) {
+	cases := []struct {
+		resourceType string
+		provider     string
+		rest         string
+		ok           bool
+	}{
+		{"aws_s3_bucket", "aws", "s3_bucket", true},
+		{"google_compute_instance", "google", "compute_instance", true},
+		{"_leading_underscore", "", "", false},
+		{"nounderscore", "", "", false},
+	}
+	for _, c := range cases {
+		provider, rest, ok := splitResourceType(c.resourceType)
+		if provider != c.provider || rest != c.rest || ok != c.ok {
+			t.Errorf("splitResourceType(%q) = (%q, %q, %v), want (%q, %q, %v)",
+				c.resourceType, provider, rest, ok, c.provider, c.rest, c.ok)
+		}
+	}
+}
+
+func TestPulumiRuntime(t *testing.T) {
+	cases := []struct {
+		language string
+		runtime  string
+		ok       bool
+	}{
+		{LanguageTypescript, "nodejs", true},
+		{LanguagePython, "python", true},
+		{"unknown", "", false},
+	}
+	for _, c := range cases {
+		runtime, ok := pulumiRuntime(c.language)
+		if runtime != c.runtime || ok != c.ok {
+			t.Errorf("pulumiRuntime(%q) = (%q, %v), want (%q, %v)", c.language, runtime, ok, c.runtime, c.ok)
+		}
+	}
+}
+
+func TestProjectNameOrDefault(t *testing.T) {
+	if got := projectNameOrDefault(Options{}); got != "tf2pulumi" {
+		t.Errorf("projectNameOrDefault(Options{}) = %q, want %q", got, "tf2pulumi")
+	}
+	if got := projectNameOrDefault(Options{ProjectName: "my-infra"}); got != "my-infra" {
+		t.Errorf("projectNameOrDefault(Options{ProjectName: %q}) = %q, want %q", "my-infra", got, "my-infra")
+	}
+}
+
+func TestDiagnosticCollectorWrite(t *testing.T) {
+	c := &diagnosticCollector{}
+	if _, err := c.Write([]byte("missing provider \"aws\"\n")); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+	if _, err := c.Write([]byte("\n")); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+
+	if len(c.diagnostics) != 1 {
+		t.Fatalf("got %d diagnostics, want 1 (blank lines should not produce a Diagnostic): %v", len(c.diagnostics), c.diagnostics)
+	}
+	if c.diagnostics[0].Summary != `missing provider "aws"` {
+		t.Errorf("diagnostic summary = %q, want %q", c.diagnostics[0].Summary, `missing provider "aws"`)
+	}
+}